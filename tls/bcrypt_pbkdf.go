@@ -0,0 +1,97 @@
+package tls
+
+// This file is a vendored copy of the bcrypt_pbkdf key derivation function
+// used by OpenSSH to encrypt private keys, ported from the (internal, and
+// therefore unimportable outside golang.org/x/crypto) implementation at
+// golang.org/x/crypto/ssh/internal/bcrypt_pbkdf. It implements the same
+// OpenBSD bcrypt_pbkdf(3) construction: a PBKDF2-style loop whose PRF is the
+// raw bcrypt block cipher keyed by a SHA-512 digest of the password and salt.
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+const bcryptPBKDFBlockSize = 32
+
+func bcryptHash(sha2pass, sha2salt []byte) []byte {
+	cipherText := []byte("OxychromaticBlowfishSwatDynamite")
+
+	c, err := blowfish.NewSaltedCipher(sha2pass, sha2salt)
+	if err != nil {
+		panic("bcrypt_pbkdf: " + err.Error())
+	}
+	for i := 0; i < 64; i++ {
+		blowfish.ExpandKey(sha2salt, c)
+		blowfish.ExpandKey(sha2pass, c)
+	}
+
+	out := make([]byte, len(cipherText))
+	copy(out, cipherText)
+	for i := 0; i < len(out); i += 8 {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(out[i:i+8], out[i:i+8])
+		}
+	}
+
+	// Swap bytes due to different endianness between the original
+	// implementation and the block cipher's byte order.
+	for i := 0; i < len(out); i += 4 {
+		out[i+0], out[i+3] = out[i+3], out[i+0]
+		out[i+1], out[i+2] = out[i+2], out[i+1]
+	}
+	return out
+}
+
+// bcryptPBKDF derives a keyLen-byte key from password and salt using rounds
+// iterations of the bcrypt_pbkdf construction.
+func bcryptPBKDF(password, salt []byte, rounds, keyLen int) ([]byte, error) {
+	if rounds < 1 {
+		return nil, errors.New("bcrypt_pbkdf: number of rounds is too small")
+	}
+	if len(password) == 0 {
+		return nil, errors.New("bcrypt_pbkdf: empty password")
+	}
+	if len(salt) == 0 || len(salt) > 1<<20 {
+		return nil, errors.New("bcrypt_pbkdf: bad salt length")
+	}
+
+	numBlocks := (keyLen + bcryptPBKDFBlockSize - 1) / bcryptPBKDFBlockSize
+	key := make([]byte, numBlocks*bcryptPBKDFBlockSize)
+
+	h := sha512.New()
+	h.Write(password)
+	shaPass := h.Sum(nil)
+
+	for block := 1; block <= numBlocks; block++ {
+		h.Reset()
+		h.Write(salt)
+		var counter [4]byte
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		h.Write(counter[:])
+		shaSalt := h.Sum(nil)
+
+		out := bcryptHash(shaPass, shaSalt)
+		tmp := make([]byte, len(out))
+		copy(tmp, out)
+
+		for i := 1; i < rounds; i++ {
+			h.Reset()
+			h.Write(tmp)
+			shaSalt = h.Sum(nil)
+			tmp = bcryptHash(shaPass, shaSalt)
+			for j := range out {
+				out[j] ^= tmp[j]
+			}
+		}
+
+		for i, b := range out {
+			key[i*numBlocks+(block-1)] = b
+		}
+	}
+
+	return key[:keyLen], nil
+}
@@ -0,0 +1,91 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// secp256k1OID is the well-known OID for the secp256k1 curve (SEC 2,
+// section A.2.1), used for ES256K/JWS and most blockchain key formats. It is
+// not one of the NIST curves crypto/x509 knows how to marshal, so this
+// provider has to encode/decode it itself.
+var secp256k1OID = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+// isSecp256k1 reports whether curve is the secp256k1 curve, as opposed to
+// one of the stdlib NIST curves also reachable through *ecdsa.PrivateKey.
+func isSecp256k1(curve elliptic.Curve) bool {
+	return curve == btcec.S256()
+}
+
+// ecPrivateKeyASN1 mirrors the unexported ecPrivateKey struct crypto/x509
+// uses for RFC 5915 SEC1 encoding, which we can't reuse directly because it
+// refuses to marshal curves it doesn't recognize.
+type ecPrivateKeyASN1 struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// marshalSecp256k1PrivateKey encodes k as a SEC1 ECPrivateKey, the same
+// shape x509.MarshalECPrivateKey produces for the NIST curves.
+func marshalSecp256k1PrivateKey(k *ecdsa.PrivateKey) ([]byte, error) {
+	privBytes := make([]byte, 32)
+	k.D.FillBytes(privBytes)
+
+	return asn1.Marshal(ecPrivateKeyASN1{
+		Version:       1,
+		PrivateKey:    privBytes,
+		NamedCurveOID: secp256k1OID,
+		PublicKey:     asn1.BitString{Bytes: elliptic.Marshal(k.Curve, k.X, k.Y)},
+	})
+}
+
+// marshalSecp256k1PublicKey encodes pub as a SubjectPublicKeyInfo, the same
+// shape x509.MarshalPKIXPublicKey produces for the NIST curves.
+func marshalSecp256k1PublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	type algorithmIdentifier struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	type subjectPublicKeyInfo struct {
+		Algorithm algorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithmIdentifier{
+			Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, // id-ecPublicKey
+			Parameters: secp256k1OID,
+		},
+		PublicKey: asn1.BitString{Bytes: elliptic.Marshal(pub.Curve, pub.X, pub.Y)},
+	})
+}
+
+// parseSecp256k1PrivateKey decodes the SEC1 ECPrivateKey produced by
+// marshalSecp256k1PrivateKey.
+func parseSecp256k1PrivateKey(der []byte) (interface{}, error) {
+	var key ecPrivateKeyASN1
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, fmt.Errorf("error parsing secp256k1 private key: %s", err)
+	}
+	if !key.NamedCurveOID.Equal(secp256k1OID) {
+		return nil, fmt.Errorf("private key does not use the secp256k1 curve")
+	}
+
+	curve := btcec.S256()
+	x, y := elliptic.Unmarshal(curve, key.PublicKey.Bytes)
+	if x == nil {
+		x, y = curve.ScalarBaseMult(key.PrivateKey)
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+		D:         new(big.Int).SetBytes(key.PrivateKey),
+	}, nil
+}
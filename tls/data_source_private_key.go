@@ -0,0 +1,112 @@
+package tls
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourcePrivateKey() *schema.Resource {
+	return &schema.Resource{
+		Read: ReadPrivateKeyData,
+
+		Schema: map[string]*schema.Schema{
+			"private_key_pem": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Private key data in PEM format: PKCS#1, SEC1, PKCS#8, or OpenSSH",
+			},
+
+			"private_key_openssh": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Private key data in OpenSSH PEM format, as an alternative to `private_key_pem`",
+			},
+
+			"passphrase": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Passphrase to decrypt `private_key_pem`/`private_key_openssh`, if they are encrypted",
+			},
+
+			"algorithm": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the algorithm used by the given private key",
+			},
+
+			"rsa_bits": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"ecdsa_curve": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_key_pem": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_key_openssh": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_key_fingerprint_md5": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_key_fingerprint_sha256": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ReadPrivateKeyData(d *schema.ResourceData, meta interface{}) error {
+	pemKey := d.Get("private_key_pem").(string)
+	opensshKey := d.Get("private_key_openssh").(string)
+	passphrase := d.Get("passphrase").(string)
+
+	var source string
+	switch {
+	case pemKey != "":
+		source = pemKey
+	case opensshKey != "":
+		source = opensshKey
+	default:
+		return fmt.Errorf("one of \"private_key_pem\" or \"private_key_openssh\" must be set")
+	}
+
+	key, err := parsePrivateKey([]byte(source), passphrase)
+	if err != nil {
+		return fmt.Errorf("error parsing private key: %s", err)
+	}
+
+	algorithm, rsaBits, ecdsaCurve, err := keyAlgorithmAndParams(key)
+	if err != nil {
+		return err
+	}
+	d.Set("algorithm", algorithm)
+	d.Set("rsa_bits", rsaBits)
+	d.Set("ecdsa_curve", ecdsaCurve)
+
+	if err := readPublicKey(d, key); err != nil {
+		return err
+	}
+
+	pubKeyBytesDER, err := publicKeyBytes(key)
+	if err != nil {
+		return fmt.Errorf("error marshaling public key: %s", err)
+	}
+	d.SetId(hashForState(string(pubKeyBytesDER)))
+	return nil
+}
@@ -0,0 +1,92 @@
+package tls
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestDataSourcePrivateKey_RSA(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+                    resource "tls_private_key" "input" {
+                        algorithm = "RSA"
+                    }
+                    data "tls_private_key" "test" {
+                        private_key_pem = "${tls_private_key.input.private_key_pem}"
+                    }
+                    output "algorithm" {
+                        value = "${data.tls_private_key.test.algorithm}"
+                    }
+                    output "public_key_pem" {
+                        value = "${data.tls_private_key.test.public_key_pem}"
+                    }
+                    output "public_key_openssh" {
+                        value = "${data.tls_private_key.test.public_key_openssh}"
+                    }
+                `,
+				Check: func(s *terraform.State) error {
+					gotAlgorithm := s.RootModule().Outputs["algorithm"].Value.(string)
+					if gotAlgorithm != "RSA" {
+						return fmt.Errorf("data source reported algorithm %#v, want \"RSA\"", gotAlgorithm)
+					}
+
+					gotPublic := s.RootModule().Outputs["public_key_pem"].Value.(string)
+					if !strings.HasPrefix(gotPublic, "-----BEGIN PUBLIC KEY----") {
+						return fmt.Errorf("public key is missing public key PEM preamble")
+					}
+
+					gotPublicSSH := s.RootModule().Outputs["public_key_openssh"].Value.(string)
+					if !strings.HasPrefix(gotPublicSSH, "ssh-rsa ") {
+						return fmt.Errorf("SSH public key is missing ssh-rsa prefix")
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestDataSourcePrivateKey_OpenSSH(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+                    resource "tls_private_key" "input" {
+                        algorithm = "ED25519"
+                    }
+                    data "tls_private_key" "test" {
+                        private_key_openssh = "${tls_private_key.input.private_key_openssh}"
+                    }
+                    output "algorithm" {
+                        value = "${data.tls_private_key.test.algorithm}"
+                    }
+                    output "public_key_openssh" {
+                        value = "${data.tls_private_key.test.public_key_openssh}"
+                    }
+                `,
+				Check: func(s *terraform.State) error {
+					gotAlgorithm := s.RootModule().Outputs["algorithm"].Value.(string)
+					if gotAlgorithm != "ED25519" {
+						return fmt.Errorf("data source reported algorithm %#v, want \"ED25519\"", gotAlgorithm)
+					}
+
+					gotPublicSSH := s.RootModule().Outputs["public_key_openssh"].Value.(string)
+					if !strings.HasPrefix(gotPublicSSH, "ssh-ed25519") {
+						return fmt.Errorf("SSH public key is missing ssh-ed25519 prefix")
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,236 @@
+package tls
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cloudflare/circl/sign/ed448"
+	"golang.org/x/crypto/ed25519"
+)
+
+// pemBlockTypeAlgorithm maps the PEM block types this provider's own
+// encoders produce back to the keyParsers key that can decode their DER
+// contents.
+var pemBlockTypeAlgorithm = map[string]string{
+	"RSA PRIVATE KEY":     "RSA",
+	"EC PRIVATE KEY":      "ECDSA",
+	"ED25519 PRIVATE KEY": "ED25519",
+	"ED448 PRIVATE KEY":   "ED448",
+}
+
+// parsePrivateKey decodes a PEM-encoded private key in any of the forms this
+// provider can produce or accept (PKCS#1, SEC1, PKCS#8, or OpenSSH v1),
+// detecting the key's algorithm from the PEM block type / OpenSSH magic
+// rather than requiring the caller to declare it up front.
+func parsePrivateKey(pemData []byte, passphrase string) (interface{}, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in private key data")
+	}
+
+	if block.Type == "OPENSSH PRIVATE KEY" {
+		return parseOpenSSHPrivateKey(block.Bytes, passphrase)
+	}
+
+	if x509.IsEncryptedPEMBlock(block) {
+		decrypted, err := x509.DecryptPEMBlock(block, []byte(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting private key: %s", err)
+		}
+		block.Bytes = decrypted
+	}
+
+	if block.Type == "PRIVATE KEY" {
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+
+	algorithm, ok := pemBlockTypeAlgorithm[block.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key PEM type %q", block.Type)
+	}
+	return keyParsers[algorithm](block.Bytes)
+}
+
+// sshWireReader incrementally decodes the big-endian length-prefixed fields
+// used throughout the SSH wire format (RFC 4251 section 5), sticking at the
+// first error encountered so callers can check it once at the end of a
+// sequence of reads.
+type sshWireReader struct {
+	data []byte
+	err  error
+}
+
+func (r *sshWireReader) uint32() uint32 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data) < 4 {
+		r.err = fmt.Errorf("truncated OpenSSH key data")
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.data[:4])
+	r.data = r.data[4:]
+	return v
+}
+
+func (r *sshWireReader) bytes() []byte {
+	n := r.uint32()
+	if r.err != nil {
+		return nil
+	}
+	if uint32(len(r.data)) < n {
+		r.err = fmt.Errorf("truncated OpenSSH key data")
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *sshWireReader) string() string {
+	return string(r.bytes())
+}
+
+func (r *sshWireReader) mpint() *big.Int {
+	return new(big.Int).SetBytes(r.bytes())
+}
+
+// parseOpenSSHPrivateKey is the inverse of marshalOpenSSHPrivateKey: it
+// decodes a single-key "openssh-key-v1" private key blob, decrypting it
+// first if it was encrypted with a passphrase.
+func parseOpenSSHPrivateKey(data []byte, passphrase string) (interface{}, error) {
+	if len(data) < len(opensshMagic) || string(data[:len(opensshMagic)]) != opensshMagic {
+		return nil, fmt.Errorf("invalid OpenSSH private key: missing magic header")
+	}
+
+	r := &sshWireReader{data: data[len(opensshMagic):]}
+	cipherName := r.string()
+	kdfName := r.string()
+	kdfOptions := r.bytes()
+	numKeys := r.uint32()
+	r.bytes() // public key blob; redundant with the private section below
+	private := r.bytes()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if numKeys != 1 {
+		return nil, fmt.Errorf("only single-key OpenSSH private key files are supported")
+	}
+
+	if cipherName != "none" {
+		if passphrase == "" {
+			return nil, fmt.Errorf("private key is encrypted and requires a passphrase")
+		}
+		if cipherName != "aes256-ctr" || kdfName != "bcrypt" {
+			return nil, fmt.Errorf("unsupported OpenSSH cipher/kdf %q/%q", cipherName, kdfName)
+		}
+
+		kdfR := &sshWireReader{data: kdfOptions}
+		salt := kdfR.bytes()
+		rounds := kdfR.uint32()
+		if kdfR.err != nil {
+			return nil, kdfR.err
+		}
+
+		derived, err := bcryptPBKDF([]byte(passphrase), salt, int(rounds), 48)
+		if err != nil {
+			return nil, err
+		}
+		block, err := aes.NewCipher(derived[:32])
+		if err != nil {
+			return nil, err
+		}
+		cipher.NewCTR(block, derived[32:48]).XORKeyStream(private, private)
+	}
+
+	pr := &sshWireReader{data: private}
+	checkint1 := pr.uint32()
+	checkint2 := pr.uint32()
+	if pr.err == nil && checkint1 != checkint2 {
+		return nil, fmt.Errorf("incorrect passphrase or corrupt OpenSSH private key")
+	}
+
+	keyType := pr.string()
+	switch keyType {
+	case "ssh-rsa":
+		n := pr.mpint()
+		e := pr.mpint()
+		d := pr.mpint()
+		pr.mpint() // iqmp, recomputed by Precompute below
+		p := pr.mpint()
+		q := pr.mpint()
+		if pr.err != nil {
+			return nil, pr.err
+		}
+		key := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: n, E: int(e.Int64())},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		key.Precompute()
+		return key, nil
+
+	case "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384", "ecdsa-sha2-nistp521", "ecdsa-sha2-nistp256k1":
+		curveName := pr.string()
+		point := pr.bytes()
+		d := pr.mpint()
+		if pr.err != nil {
+			return nil, pr.err
+		}
+		var curve elliptic.Curve
+		switch curveName {
+		case "nistp256":
+			curve = elliptic.P256()
+		case "nistp384":
+			curve = elliptic.P384()
+		case "nistp521":
+			curve = elliptic.P521()
+		case "nistp256k1":
+			curve = btcec.S256()
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve %q", curveName)
+		}
+		x, y := elliptic.Unmarshal(curve, point)
+		if x == nil {
+			return nil, fmt.Errorf("invalid EC point in OpenSSH private key")
+		}
+		return &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+			D:         d,
+		}, nil
+
+	case "ssh-ed25519":
+		pr.bytes() // public key, derivable from the private key below
+		priv := pr.bytes()
+		if pr.err != nil {
+			return nil, pr.err
+		}
+		if len(priv) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 private key length")
+		}
+		return ed25519.PrivateKey(priv), nil
+
+	case "ssh-ed448":
+		pr.bytes() // public key, derivable from the private key below
+		priv := pr.bytes()
+		if pr.err != nil {
+			return nil, pr.err
+		}
+		if len(priv) != ed448.PrivateKeySize {
+			return nil, fmt.Errorf("invalid Ed448 private key length")
+		}
+		return ed448.PrivateKey(priv), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported OpenSSH key type %q", keyType)
+	}
+}
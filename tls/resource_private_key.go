@@ -1,16 +1,28 @@
 package tls
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/md5"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"math/big"
+	"strings"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cloudflare/circl/sign/ed448"
 	"github.com/hashicorp/terraform/helper/schema"
 	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
 )
 
 type keyAlgo func(d *schema.ResourceData) (interface{}, error)
@@ -32,8 +44,10 @@ var keyAlgos map[string]keyAlgo = map[string]keyAlgo{
 			return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
 		case "P521":
 			return ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+		case "secp256k1":
+			return ecdsa.GenerateKey(btcec.S256(), rand.Reader)
 		default:
-			return nil, fmt.Errorf("invalid ecdsa_curve; must be P224, P256, P384 or P521")
+			return nil, fmt.Errorf("invalid ecdsa_curve; must be P224, P256, P384, P521 or secp256k1")
 		}
 	},
 	"ED25519": func(d *schema.ResourceData) (interface{}, error) {
@@ -42,6 +56,10 @@ var keyAlgos map[string]keyAlgo = map[string]keyAlgo{
 			return priv, err
 		}()
 	},
+	"ED448": func(d *schema.ResourceData) (interface{}, error) {
+		_, priv, err := ed448.GenerateKey(rand.Reader)
+		return priv, err
+	},
 }
 
 var keyParsers map[string]keyParser = map[string]keyParser{
@@ -49,11 +67,19 @@ var keyParsers map[string]keyParser = map[string]keyParser{
 		return x509.ParsePKCS1PrivateKey(der)
 	},
 	"ECDSA": func(der []byte) (interface{}, error) {
-		return x509.ParseECPrivateKey(der)
+		// secp256k1 isn't a curve crypto/x509 knows about, so a generic EC
+		// PRIVATE KEY block may be one of ours (see marshalSecp256k1PrivateKey).
+		if key, err := x509.ParseECPrivateKey(der); err == nil {
+			return key, nil
+		}
+		return parseSecp256k1PrivateKey(der)
 	},
 	"ED25519": func(der []byte) (interface{}, error) {
 		return ed25519.NewKeyFromSeed(der), nil
 	},
+	"ED448": func(der []byte) (interface{}, error) {
+		return ed448.NewKeyFromSeed(der), nil
+	},
 }
 
 func resourcePrivateKey() *schema.Resource {
@@ -81,7 +107,7 @@ func resourcePrivateKey() *schema.Resource {
 			"ecdsa_curve": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "ECDSA curve to use when generating a key",
+				Description: "ECDSA curve to use when generating a key; P224, P256, P384, P521 or secp256k1",
 				ForceNew:    true,
 				Default:     "P224",
 			},
@@ -105,12 +131,85 @@ func resourcePrivateKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"public_key_fingerprint_sha256": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 fingerprint of the public key data in OpenSSH format, as computed by `ssh-keygen -lf`",
+			},
+
+			"public_key_authorized_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public key data in the format used by `~/.ssh/authorized_keys`, including the trailing newline",
+			},
+
+			"passphrase": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				ForceNew:    true,
+				Description: "Passphrase used to encrypt `private_key_pem` and `private_key_openssh`; `private_key_jwk` has no way to carry a passphrase, so it is left empty instead",
+			},
+
+			"private_key_openssh": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Private key data in the OpenSSH \"openssh-key-v1\" PEM format",
+			},
+
+			"kid": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "`kid` to embed in `private_key_jwk` and `public_key_jwk`; defaults to the key's RFC 7638 thumbprint",
+				ForceNew:    true,
+			},
+
+			"private_key_jwk": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Private key data in RFC 7517 JWK format",
+			},
+
+			"public_key_jwk": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public key data in RFC 7517 JWK format",
+			},
+
+			"signer": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				ForceNew:    true,
+				Description: "External signer backend to use when `algorithm = \"EXTERNAL\"`, so private key material never enters Terraform state",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backend": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the registered signer backend (`ssh-agent` or `pkcs11`)",
+						},
+						"config": &schema.Schema{
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Backend-specific configuration, e.g. `comment`/`fingerprint` for ssh-agent or `module`/`slot`/`label`/`pin` for pkcs11",
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 func CreatePrivateKey(d *schema.ResourceData, meta interface{}) error {
 	keyAlgoName := d.Get("algorithm").(string)
+
+	if keyAlgoName == "EXTERNAL" {
+		return createExternalPrivateKey(d)
+	}
+
 	var keyFunc keyAlgo
 	var ok bool
 	if keyFunc, ok = keyAlgos[keyAlgoName]; !ok {
@@ -130,7 +229,13 @@ func CreatePrivateKey(d *schema.ResourceData, meta interface{}) error {
 			Bytes: x509.MarshalPKCS1PrivateKey(k),
 		}
 	case *ecdsa.PrivateKey:
-		keyBytes, err := x509.MarshalECPrivateKey(k)
+		var keyBytes []byte
+		var err error
+		if isSecp256k1(k.Curve) {
+			keyBytes, err = marshalSecp256k1PrivateKey(k)
+		} else {
+			keyBytes, err = x509.MarshalECPrivateKey(k)
+		}
 		if err != nil {
 			return fmt.Errorf("error encoding key to PEM: %s", err)
 		}
@@ -143,13 +248,100 @@ func CreatePrivateKey(d *schema.ResourceData, meta interface{}) error {
 			Type:  "ED25519 PRIVATE KEY",
 			Bytes: k.Seed(),
 		}
+	case ed448.PrivateKey:
+		keyPemBlock = &pem.Block{
+			Type:  "ED448 PRIVATE KEY",
+			Bytes: k.Seed(),
+		}
 	default:
 		return fmt.Errorf("unsupported private key type")
 	}
+	passphrase := d.Get("passphrase").(string)
+	if passphrase != "" {
+		encryptedBlock, err := x509.EncryptPEMBlock(rand.Reader, keyPemBlock.Type, keyPemBlock.Bytes, []byte(passphrase), x509.PEMCipherAES256)
+		if err != nil {
+			return fmt.Errorf("error encrypting PEM block: %s", err)
+		}
+		keyPemBlock = encryptedBlock
+	}
 	keyPem := string(pem.EncodeToMemory(keyPemBlock))
 
 	d.Set("private_key_pem", keyPem)
-	return readPublicKey(d, key)
+
+	openSSHKey, err := marshalOpenSSHPrivateKey(key, passphrase, opensshDefaultKDFRounds)
+	if err != nil {
+		return fmt.Errorf("error encoding key to OpenSSH format: %s", err)
+	}
+	d.Set("private_key_openssh", openSSHKey)
+
+	privateJWK, publicJWK, kid, err := marshalJWKPair(key, d.Get("kid").(string))
+	if err != nil {
+		// e.g. the P224 curve, which has no registered JOSE curve name;
+		// skip it the same way the other format-specific outputs do.
+		d.Set("private_key_jwk", "")
+		d.Set("public_key_jwk", "")
+	} else {
+		if passphrase != "" {
+			// private_key_jwk has no passphrase protection of its own
+			// (unlike the PEM/OpenSSH encodings above); omit it rather than
+			// ship a plaintext copy of the key next to the encrypted ones.
+			privateJWK = ""
+		}
+		d.Set("private_key_jwk", privateJWK)
+		d.Set("public_key_jwk", publicJWK)
+		d.Set("kid", kid)
+	}
+
+	if err := readPublicKey(d, key); err != nil {
+		return err
+	}
+
+	pubKeyBytesDER, err := publicKeyBytes(key)
+	if err != nil {
+		return fmt.Errorf("error marshaling public key: %s", err)
+	}
+	d.SetId(hashForState(string(pubKeyBytesDER)))
+
+	return nil
+}
+
+// createExternalPrivateKey handles algorithm = "EXTERNAL": it hands off to
+// the configured signer backend instead of generating key material, and
+// only ever populates the public-key-derived computed attributes.
+func createExternalPrivateKey(d *schema.ResourceData) error {
+	signerBlocks := d.Get("signer").([]interface{})
+	if len(signerBlocks) != 1 {
+		return fmt.Errorf("algorithm = \"EXTERNAL\" requires exactly one \"signer\" block")
+	}
+	signerBlock := signerBlocks[0].(map[string]interface{})
+
+	backendName := signerBlock["backend"].(string)
+	newSigner, ok := signerBackends[backendName]
+	if !ok {
+		return fmt.Errorf("unknown signer backend %#v", backendName)
+	}
+
+	config, _ := signerBlock["config"].(map[string]interface{})
+	signer, err := newSigner(config)
+	if err != nil {
+		return fmt.Errorf("error initializing %q signer: %s", backendName, err)
+	}
+
+	d.Set("private_key_pem", "")
+	d.Set("private_key_openssh", "")
+	d.Set("private_key_jwk", "")
+	d.Set("public_key_jwk", "")
+
+	if err := readPublicKey(d, signer.Public()); err != nil {
+		return err
+	}
+
+	pubKeyBytesDER, err := publicKeyBytes(signer.Public())
+	if err != nil {
+		return fmt.Errorf("error marshaling public key: %s", err)
+	}
+	d.SetId(hashForState(string(pubKeyBytesDER)))
+	return nil
 }
 
 func DeletePrivateKey(d *schema.ResourceData, meta interface{}) error {
@@ -161,6 +353,70 @@ func ReadPrivateKey(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// hashForState computes a hex-encoded SHA1 hash of s, for use as a resource
+// ID derived from generated data that has no natural identifier of its own.
+// Unlike public_key_fingerprint_sha256, it is never empty for a key this
+// provider can successfully marshal, even for curves (e.g. P224) that
+// golang.org/x/crypto/ssh can't represent.
+func hashForState(s string) string {
+	if s == "" {
+		return ""
+	}
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// readPublicKey derives every public-key-shaped computed attribute
+// (public_key_pem, public_key_openssh, public_key_authorized_key, and the
+// MD5/SHA256 fingerprints) from priv and sets them on d.
+func readPublicKey(d *schema.ResourceData, priv interface{}) error {
+	pubKeyBytesDER, err := publicKeyBytes(priv)
+	if err != nil {
+		return fmt.Errorf("error marshaling public key: %s", err)
+	}
+	d.Set("public_key_pem", string(pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: pubKeyBytesDER,
+	})))
+
+	sshPubKey, err := sshPublicKeyFor(priv)
+	if err != nil {
+		// e.g. the P224 curve, which has no SSH wire representation at all
+		d.Set("public_key_openssh", "")
+		d.Set("public_key_authorized_key", "")
+		d.Set("public_key_fingerprint_md5", "")
+		d.Set("public_key_fingerprint_sha256", "")
+		return nil
+	}
+
+	authorizedKey := string(ssh.MarshalAuthorizedKey(sshPubKey))
+	d.Set("public_key_openssh", authorizedKey)
+	d.Set("public_key_authorized_key", authorizedKey)
+
+	sshPubKeyBytes := sshPubKey.Marshal()
+
+	md5Sum := md5.Sum(sshPubKeyBytes)
+	d.Set("public_key_fingerprint_md5", formatFingerprintMD5(md5Sum))
+
+	sha256Sum := sha256.Sum256(sshPubKeyBytes)
+	d.Set("public_key_fingerprint_sha256", "SHA256:"+base64.RawStdEncoding.EncodeToString(sha256Sum[:]))
+
+	return nil
+}
+
+// formatFingerprintMD5 renders an MD5 fingerprint as colon-separated hex
+// pairs, matching the format `ssh-keygen -lf` uses for MD5 fingerprints.
+func formatFingerprintMD5(sum [md5.Size]byte) string {
+	hexParts := make([]string, len(sum))
+	for i, b := range sum {
+		hexParts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(hexParts, ":")
+}
+
+// publicKey returns the public key counterpart of priv, which may be either
+// a private key (as generated or parsed by this provider) or, for externally
+// signed keys, an already-public key.
 func publicKey(priv interface{}) interface{} {
 	switch k := priv.(type) {
 	case *rsa.PrivateKey:
@@ -169,16 +425,330 @@ func publicKey(priv interface{}) interface{} {
 		return &k.PublicKey
 	case ed25519.PrivateKey:
 		return k.Public()
+	case ed448.PrivateKey:
+		return k.Public()
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey, ed448.PublicKey:
+		return k
 	default:
 		return nil
 	}
 }
 
+// jwkRSAKey is the RFC 7517/7518 JSON representation of an RSA key. Private
+// components are omitted (via `omitempty`) when encoding the public JWK.
+type jwkRSAKey struct {
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	D   string `json:"d,omitempty"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	Dp  string `json:"dp,omitempty"`
+	Dq  string `json:"dq,omitempty"`
+	Qi  string `json:"qi,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// jwkECKey is the RFC 7517/7518 JSON representation of an ECDSA key. `D` is
+// left empty when encoding the public JWK.
+type jwkECKey struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	D   string `json:"d,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// jwkOKPKey is the RFC 7517/8037 JSON representation of an Ed25519 or Ed448
+// key. `D` is left empty when encoding the public JWK.
+type jwkOKPKey struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	D   string `json:"d,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// base64URLUint encodes i as big-endian, minimum-length, unpadded base64url,
+// as required by JWK integer members.
+func base64URLUint(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+// base64URLPadded encodes b as unpadded base64url after left-padding it with
+// zero bytes to size, as required for EC JWK coordinates.
+func base64URLPadded(b []byte, size int) string {
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return base64.RawURLEncoding.EncodeToString(padded)
+}
+
+// thumbprintMember is one required member of a JWK thumbprint input, per
+// RFC 7638 section 3.
+type thumbprintMember struct {
+	name  string
+	value string
+}
+
+// rfc7638Thumbprint computes the SHA-256 RFC 7638 thumbprint over the given
+// members, which must already be in the lexicographic order RFC 7638
+// requires.
+func rfc7638Thumbprint(members []thumbprintMember) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		nameJSON, err := json.Marshal(m.name)
+		if err != nil {
+			return "", err
+		}
+		valueJSON, err := json.Marshal(m.value)
+		if err != nil {
+			return "", err
+		}
+		buf.Write(nameJSON)
+		buf.WriteByte(':')
+		buf.Write(valueJSON)
+	}
+	buf.WriteByte('}')
+
+	sum := sha256.Sum256(buf.Bytes())
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// marshalJWKPair encodes priv as a pair of RFC 7517 JWK JSON documents, one
+// with and one without the private components, dispatching on the same
+// concrete types as the PEM switch in CreatePrivateKey. If kidOverride is
+// empty, the `kid` member defaults to the RFC 7638 thumbprint of the key.
+func marshalJWKPair(priv interface{}, kidOverride string) (privateJWK string, publicJWK string, kid string, err error) {
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		return marshalRSAJWK(k, kidOverride)
+	case *ecdsa.PrivateKey:
+		return marshalECDSAJWK(k, kidOverride)
+	case ed25519.PrivateKey:
+		return marshalEd25519JWK(k, kidOverride)
+	case ed448.PrivateKey:
+		return marshalEd448JWK(k, kidOverride)
+	default:
+		return "", "", "", fmt.Errorf("unsupported private key type")
+	}
+}
+
+func marshalRSAJWK(k *rsa.PrivateKey, kidOverride string) (string, string, string, error) {
+	n := base64URLUint(k.N)
+	e := base64URLUint(big.NewInt(int64(k.E)))
+
+	kid := kidOverride
+	if kid == "" {
+		thumb, err := rfc7638Thumbprint([]thumbprintMember{
+			{"e", e},
+			{"kty", "RSA"},
+			{"n", n},
+		})
+		if err != nil {
+			return "", "", "", err
+		}
+		kid = thumb
+	}
+
+	pub := jwkRSAKey{Kty: "RSA", N: n, E: e, Kid: kid}
+	pubJSON, err := json.Marshal(pub)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	k.Precompute()
+	priv := pub
+	priv.D = base64URLUint(k.D)
+	priv.P = base64URLUint(k.Primes[0])
+	priv.Q = base64URLUint(k.Primes[1])
+	priv.Dp = base64URLUint(k.Precomputed.Dp)
+	priv.Dq = base64URLUint(k.Precomputed.Dq)
+	priv.Qi = base64URLUint(k.Precomputed.Qinv)
+	privJSON, err := json.Marshal(priv)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return string(privJSON), string(pubJSON), kid, nil
+}
+
+func marshalECDSAJWK(k *ecdsa.PrivateKey, kidOverride string) (string, string, string, error) {
+	var crv string
+	var size int
+	switch k.Curve {
+	case elliptic.P256():
+		crv, size = "P-256", 32
+	case elliptic.P384():
+		crv, size = "P-384", 48
+	case elliptic.P521():
+		crv, size = "P-521", 66
+	case btcec.S256():
+		crv, size = "secp256k1", 32
+	default:
+		return "", "", "", fmt.Errorf("unsupported ECDSA curve for JWK encoding")
+	}
+
+	x := base64URLPadded(k.X.Bytes(), size)
+	y := base64URLPadded(k.Y.Bytes(), size)
+
+	kid := kidOverride
+	if kid == "" {
+		thumb, err := rfc7638Thumbprint([]thumbprintMember{
+			{"crv", crv},
+			{"kty", "EC"},
+			{"x", x},
+			{"y", y},
+		})
+		if err != nil {
+			return "", "", "", err
+		}
+		kid = thumb
+	}
+
+	pub := jwkECKey{Kty: "EC", Crv: crv, X: x, Y: y, Kid: kid}
+	pubJSON, err := json.Marshal(pub)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	priv := pub
+	priv.D = base64URLPadded(k.D.Bytes(), size)
+	privJSON, err := json.Marshal(priv)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return string(privJSON), string(pubJSON), kid, nil
+}
+
+func marshalEd25519JWK(k ed25519.PrivateKey, kidOverride string) (string, string, string, error) {
+	pub, ok := k.Public().(ed25519.PublicKey)
+	if !ok {
+		return "", "", "", fmt.Errorf("failed to get ed25519 public key")
+	}
+	x := base64.RawURLEncoding.EncodeToString(pub)
+
+	kid := kidOverride
+	if kid == "" {
+		thumb, err := rfc7638Thumbprint([]thumbprintMember{
+			{"crv", "Ed25519"},
+			{"kty", "OKP"},
+			{"x", x},
+		})
+		if err != nil {
+			return "", "", "", err
+		}
+		kid = thumb
+	}
+
+	pub2 := jwkOKPKey{Kty: "OKP", Crv: "Ed25519", X: x, Kid: kid}
+	pubJSON, err := json.Marshal(pub2)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	priv := pub2
+	priv.D = base64.RawURLEncoding.EncodeToString(k.Seed())
+	privJSON, err := json.Marshal(priv)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return string(privJSON), string(pubJSON), kid, nil
+}
+
+func marshalEd448JWK(k ed448.PrivateKey, kidOverride string) (string, string, string, error) {
+	pub, ok := k.Public().(ed448.PublicKey)
+	if !ok {
+		return "", "", "", fmt.Errorf("failed to get ed448 public key")
+	}
+	x := base64.RawURLEncoding.EncodeToString(pub)
+
+	kid := kidOverride
+	if kid == "" {
+		thumb, err := rfc7638Thumbprint([]thumbprintMember{
+			{"crv", "Ed448"},
+			{"kty", "OKP"},
+			{"x", x},
+		})
+		if err != nil {
+			return "", "", "", err
+		}
+		kid = thumb
+	}
+
+	pub2 := jwkOKPKey{Kty: "OKP", Crv: "Ed448", X: x, Kid: kid}
+	pubJSON, err := json.Marshal(pub2)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	priv := pub2
+	priv.D = base64.RawURLEncoding.EncodeToString(k.Seed())
+	privJSON, err := json.Marshal(priv)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return string(privJSON), string(pubJSON), kid, nil
+}
+
+// keyAlgorithmAndParams derives the provider's "algorithm" name and the
+// corresponding rsa_bits/ecdsa_curve schema values from an already-parsed
+// key, the same three pieces of information resourcePrivateKey normally
+// takes as input. It is used by the tls_private_key data source to infer
+// them from imported key material instead.
+func keyAlgorithmAndParams(key interface{}) (algorithm string, rsaBits int, ecdsaCurve string, err error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return "RSA", k.N.BitLen(), "", nil
+	case *ecdsa.PrivateKey:
+		curveName, err := ecdsaCurveSchemaName(k.Curve)
+		if err != nil {
+			return "", 0, "", err
+		}
+		return "ECDSA", 0, curveName, nil
+	case ed25519.PrivateKey:
+		return "ED25519", 0, "", nil
+	case ed448.PrivateKey:
+		return "ED448", 0, "", nil
+	default:
+		return "", 0, "", fmt.Errorf("unsupported private key type")
+	}
+}
+
+// ecdsaCurveSchemaName maps a curve back to the string accepted by the
+// ecdsa_curve schema attribute.
+func ecdsaCurveSchemaName(curve elliptic.Curve) (string, error) {
+	switch curve {
+	case elliptic.P224():
+		return "P224", nil
+	case elliptic.P256():
+		return "P256", nil
+	case elliptic.P384():
+		return "P384", nil
+	case elliptic.P521():
+		return "P521", nil
+	case btcec.S256():
+		return "secp256k1", nil
+	default:
+		return "", fmt.Errorf("unsupported ECDSA curve")
+	}
+}
+
 func publicKeyBytes(priv interface{}) ([]byte, error) {
 	switch k := priv.(type) {
 	case *rsa.PrivateKey:
 		return x509.MarshalPKIXPublicKey(&k.PublicKey)
 	case *ecdsa.PrivateKey:
+		if isSecp256k1(k.Curve) {
+			return marshalSecp256k1PublicKey(&k.PublicKey)
+		}
 		return x509.MarshalPKIXPublicKey(&k.PublicKey)
 	case ed25519.PrivateKey:
 		pubKey, ok := k.Public().(ed25519.PublicKey)
@@ -186,6 +756,23 @@ func publicKeyBytes(priv interface{}) ([]byte, error) {
 			return nil, fmt.Errorf("failed to get ed25519 public key")
 		}
 		return []byte(pubKey), nil
+	case ed448.PrivateKey:
+		pubKey, ok := k.Public().(ed448.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("failed to get ed448 public key")
+		}
+		return []byte(pubKey), nil
+	case *rsa.PublicKey:
+		return x509.MarshalPKIXPublicKey(k)
+	case *ecdsa.PublicKey:
+		if isSecp256k1(k.Curve) {
+			return marshalSecp256k1PublicKey(k)
+		}
+		return x509.MarshalPKIXPublicKey(k)
+	case ed25519.PublicKey:
+		return []byte(k), nil
+	case ed448.PublicKey:
+		return []byte(k), nil
 	default:
 		return nil, fmt.Errorf("unsupported private key type")
 	}
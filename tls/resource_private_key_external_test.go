@@ -0,0 +1,84 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// fakeSigner is a Signer backed by an in-memory RSA key, registered under
+// the "fake" backend name so algorithm = "EXTERNAL" can be exercised in
+// tests without a real ssh-agent or PKCS#11 token.
+type fakeSigner struct {
+	key *rsa.PrivateKey
+}
+
+func init() {
+	signerBackends["fake"] = func(config map[string]interface{}) (Signer, error) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return fakeSigner{key: key}, nil
+	}
+}
+
+func (f fakeSigner) Public() crypto.PublicKey {
+	return &f.key.PublicKey
+}
+
+func (f fakeSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand, f.key, opts.HashFunc(), digest)
+}
+
+func TestPrivateKeyExternal(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+                    resource "tls_private_key" "test" {
+                        algorithm = "EXTERNAL"
+                        signer {
+                            backend = "fake"
+                        }
+                    }
+                    output "private_key_pem" {
+                        value = "${tls_private_key.test.private_key_pem}"
+                    }
+                    output "public_key_pem" {
+                        value = "${tls_private_key.test.public_key_pem}"
+                    }
+                    output "public_key_openssh" {
+                        value = "${tls_private_key.test.public_key_openssh}"
+                    }
+                `,
+				Check: func(s *terraform.State) error {
+					gotPrivate := s.RootModule().Outputs["private_key_pem"].Value.(string)
+					if gotPrivate != "" {
+						return fmt.Errorf("algorithm = \"EXTERNAL\" must not emit private_key_pem")
+					}
+
+					gotPublic := s.RootModule().Outputs["public_key_pem"].Value.(string)
+					if !strings.HasPrefix(gotPublic, "-----BEGIN PUBLIC KEY----") {
+						return fmt.Errorf("public key is missing public key PEM preamble")
+					}
+
+					gotPublicSSH := s.RootModule().Outputs["public_key_openssh"].Value.(string)
+					if !strings.HasPrefix(gotPublicSSH, "ssh-rsa ") {
+						return fmt.Errorf("SSH public key is missing ssh-rsa prefix")
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,23 @@
+package tls
+
+import (
+	"crypto"
+	"io"
+)
+
+// Signer is implemented by external key backends (ssh-agent, PKCS#11, ...)
+// that hold private key material tls_private_key never sees directly, so
+// that material never enters Terraform state when algorithm = "EXTERNAL".
+//
+// Wiring an external Signer into certificate issuance (tls_self_signed_cert,
+// tls_cert_request) is out of scope here: neither resource exists in this
+// provider yet, so there is nothing to pass a Signer into.
+type Signer interface {
+	Public() crypto.PublicKey
+	Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+}
+
+// signerBackends are the registered external signer backends, keyed by the
+// `backend` name used in a tls_private_key `signer` block. Backends
+// self-register via an init() in their own file.
+var signerBackends = map[string]func(config map[string]interface{}) (Signer, error){}
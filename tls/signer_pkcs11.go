@@ -0,0 +1,167 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/miekg/pkcs11"
+)
+
+func init() {
+	signerBackends["pkcs11"] = newPKCS11Signer
+}
+
+// pkcs11Signer implements Signer against a key held on a PKCS#11 token. The
+// private key never leaves the token: every Sign call opens its own
+// session/login, signs, and logs out again, rather than holding a session
+// open for the lifetime of the signer.
+type pkcs11Signer struct {
+	modulePath string
+	slot       uint
+	label      string
+	pin        string
+	pubKey     crypto.PublicKey
+}
+
+// newPKCS11Signer validates config["module"]/config["slot"]/config["label"]/
+// config["pin"] and looks up the public half of the key pair labeled
+// config["label"]. Schema TypeMap values always arrive as strings, so
+// "slot" is parsed with strconv rather than type-asserted to int.
+func newPKCS11Signer(config map[string]interface{}) (Signer, error) {
+	modulePath, _ := config["module"].(string)
+	label, _ := config["label"].(string)
+	pin, _ := config["pin"].(string)
+	slotStr, _ := config["slot"].(string)
+
+	if modulePath == "" || label == "" {
+		return nil, fmt.Errorf("pkcs11 signer: \"module\" and \"label\" are required")
+	}
+
+	var slot int
+	if slotStr != "" {
+		var err error
+		slot, err = strconv.Atoi(slotStr)
+		if err != nil {
+			return nil, fmt.Errorf("pkcs11 signer: invalid \"slot\" %q: %s", slotStr, err)
+		}
+	}
+
+	ctx, session, err := pkcs11OpenSession(modulePath, uint(slot), pin)
+	if err != nil {
+		return nil, err
+	}
+	defer pkcs11CloseSession(ctx, session)
+
+	pubKey, err := pkcs11PublicKey(ctx, session, label)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pkcs11Signer{modulePath: modulePath, slot: uint(slot), label: label, pin: pin, pubKey: pubKey}, nil
+}
+
+// pkcs11OpenSession loads modulePath, opens a read/write session on slot,
+// and logs in with pin. Callers must pkcs11CloseSession the result.
+func pkcs11OpenSession(modulePath string, slot uint, pin string) (*pkcs11.Ctx, pkcs11.SessionHandle, error) {
+	ctx := pkcs11.New(modulePath)
+	if ctx == nil {
+		return nil, 0, fmt.Errorf("pkcs11 signer: error loading module %q", modulePath)
+	}
+	if err := ctx.Initialize(); err != nil {
+		return nil, 0, fmt.Errorf("pkcs11 signer: error initializing module: %s", err)
+	}
+
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		ctx.Finalize()
+		return nil, 0, fmt.Errorf("pkcs11 signer: error opening session on slot %d: %s", slot, err)
+	}
+	if err := ctx.Login(session, pkcs11.CKU_USER, pin); err != nil {
+		ctx.CloseSession(session)
+		ctx.Finalize()
+		return nil, 0, fmt.Errorf("pkcs11 signer: error logging in: %s", err)
+	}
+
+	return ctx, session, nil
+}
+
+// pkcs11CloseSession logs out, closes the session, and tears the module
+// down, the inverse of pkcs11OpenSession.
+func pkcs11CloseSession(ctx *pkcs11.Ctx, session pkcs11.SessionHandle) {
+	ctx.Logout(session)
+	ctx.CloseSession(session)
+	ctx.Finalize()
+	ctx.Destroy()
+}
+
+func pkcs11FindObject(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := ctx.FindObjectsInit(session, template); err != nil {
+		return 0, fmt.Errorf("pkcs11 signer: error finding object labeled %q: %s", label, err)
+	}
+	handles, _, err := ctx.FindObjects(session, 1)
+	ctx.FindObjectsFinal(session)
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11 signer: error finding object labeled %q: %s", label, err)
+	}
+	if len(handles) == 0 {
+		return 0, fmt.Errorf("pkcs11 signer: no object labeled %q", label)
+	}
+	return handles[0], nil
+}
+
+// pkcs11PublicKey reads the RSA public key attributes for the key pair
+// labeled label. EC tokens are not yet supported by this backend.
+func pkcs11PublicKey(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, label string) (crypto.PublicKey, error) {
+	handle, err := pkcs11FindObject(ctx, session, pkcs11.CKO_PUBLIC_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, err := ctx.GetAttributeValue(session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	})
+	if err != nil || len(attrs) != 2 || len(attrs[0].Value) == 0 {
+		return nil, fmt.Errorf("pkcs11 signer: unable to read RSA public key attributes for %q (EC tokens are not yet supported): %v", label, err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(attrs[0].Value),
+		E: int(new(big.Int).SetBytes(attrs[1].Value).Int64()),
+	}, nil
+}
+
+func (s *pkcs11Signer) Public() crypto.PublicKey {
+	return s.pubKey
+}
+
+// Sign opens its own session for the duration of the call, so this signer
+// never holds a PKCS#11 session open longer than a single operation.
+func (s *pkcs11Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	ctx, session, err := pkcs11OpenSession(s.modulePath, s.slot, s.pin)
+	if err != nil {
+		return nil, err
+	}
+	defer pkcs11CloseSession(ctx, session)
+
+	handle, err := pkcs11FindObject(ctx, session, pkcs11.CKO_PRIVATE_KEY, s.label)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only RSA keys are supported until pkcs11PublicKey learns to read EC
+	// attributes (CKA_EC_POINT/CKA_EC_PARAMS).
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS, nil)}
+	if err := ctx.SignInit(session, mechanism, handle); err != nil {
+		return nil, fmt.Errorf("pkcs11 signer: error initializing signature: %s", err)
+	}
+	return ctx.Sign(session, digest)
+}
@@ -0,0 +1,86 @@
+package tls
+
+import (
+	"crypto"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func init() {
+	signerBackends["ssh-agent"] = newSSHAgentSigner
+}
+
+// sshAgentSigner implements Signer by delegating to a running ssh-agent over
+// $SSH_AUTH_SOCK, so the private key material never leaves the agent.
+type sshAgentSigner struct {
+	client agent.Agent
+	key    ssh.PublicKey
+	pubKey crypto.PublicKey
+}
+
+// newSSHAgentSigner dials $SSH_AUTH_SOCK and selects the identity matching
+// config["comment"] or config["fingerprint"] (a SHA256:... fingerprint as
+// printed by `ssh-add -l`).
+func newSSHAgentSigner(config map[string]interface{}) (Signer, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, fmt.Errorf("ssh-agent signer: SSH_AUTH_SOCK is not set")
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("ssh-agent signer: error dialing SSH_AUTH_SOCK: %s", err)
+	}
+	client := agent.NewClient(conn)
+
+	comment, _ := config["comment"].(string)
+	fingerprint, _ := config["fingerprint"].(string)
+	if comment == "" && fingerprint == "" {
+		return nil, fmt.Errorf("ssh-agent signer: one of \"comment\" or \"fingerprint\" must be set")
+	}
+
+	identities, err := client.List()
+	if err != nil {
+		return nil, fmt.Errorf("ssh-agent signer: error listing identities: %s", err)
+	}
+
+	for _, id := range identities {
+		if comment != "" && id.Comment != comment {
+			continue
+		}
+		pub, err := ssh.ParsePublicKey(id.Marshal())
+		if err != nil {
+			continue
+		}
+		if fingerprint != "" && ssh.FingerprintSHA256(pub) != fingerprint {
+			continue
+		}
+
+		cryptoPub, ok := pub.(ssh.CryptoPublicKey)
+		if !ok {
+			continue
+		}
+
+		return &sshAgentSigner{client: client, key: pub, pubKey: cryptoPub.CryptoPublicKey()}, nil
+	}
+
+	return nil, fmt.Errorf("ssh-agent signer: no identity matched comment %q / fingerprint %q", comment, fingerprint)
+}
+
+func (s *sshAgentSigner) Public() crypto.PublicKey {
+	return s.pubKey
+}
+
+// Sign delegates to the agent's own SIGN request; opts is ignored since the
+// SSH agent protocol chooses its own hash/padding for the key type.
+func (s *sshAgentSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	sig, err := s.client.Sign(s.key, digest)
+	if err != nil {
+		return nil, fmt.Errorf("ssh-agent signer: error signing: %s", err)
+	}
+	return sig.Blob, nil
+}
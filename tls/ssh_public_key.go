@@ -0,0 +1,80 @@
+package tls
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"golang.org/x/crypto/ssh"
+)
+
+// rawSSHPublicKey implements ssh.PublicKey by hand for key types
+// golang.org/x/crypto/ssh does not know how to wrap itself (secp256k1,
+// Ed448). This provider never verifies a signature, so Verify is a stub.
+type rawSSHPublicKey struct {
+	algo string
+	blob []byte
+}
+
+func (k rawSSHPublicKey) Type() string    { return k.algo }
+func (k rawSSHPublicKey) Marshal() []byte { return k.blob }
+func (k rawSSHPublicKey) Verify(data []byte, sig *ssh.Signature) error {
+	return fmt.Errorf("ssh: signature verification is not implemented for %s keys", k.algo)
+}
+
+// sshPublicKeyFor wraps the public counterpart of priv as an ssh.PublicKey,
+// falling back to a hand-rolled wire encoding for the curves/algorithms
+// golang.org/x/crypto/ssh doesn't recognize (secp256k1, Ed448). It returns an
+// error for key material with no SSH representation at all, e.g. the P224
+// curve.
+func sshPublicKeyFor(priv interface{}) (ssh.PublicKey, error) {
+	if sshPubKey, err := ssh.NewPublicKey(publicKey(priv)); err == nil {
+		return sshPubKey, nil
+	}
+
+	switch k := priv.(type) {
+	case *ecdsa.PrivateKey:
+		if !isSecp256k1(k.Curve) {
+			return nil, fmt.Errorf("unsupported ECDSA curve for SSH encoding")
+		}
+		return sshSecp256k1PublicKey(&k.PublicKey)
+	case *ecdsa.PublicKey:
+		if !isSecp256k1(k.Curve) {
+			return nil, fmt.Errorf("unsupported ECDSA curve for SSH encoding")
+		}
+		return sshSecp256k1PublicKey(k)
+	case ed448.PrivateKey:
+		pub, ok := k.Public().(ed448.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("failed to get ed448 public key")
+		}
+		return sshEd448PublicKey(pub)
+	case ed448.PublicKey:
+		return sshEd448PublicKey(k)
+	default:
+		return nil, fmt.Errorf("unsupported key type for SSH encoding")
+	}
+}
+
+func sshSecp256k1PublicKey(pub *ecdsa.PublicKey) (ssh.PublicKey, error) {
+	curveName, err := opensshECDSACurveName(pub.Curve)
+	if err != nil {
+		return nil, err
+	}
+	algo := "ecdsa-sha2-" + curveName
+
+	var buf bytes.Buffer
+	sshWriteString(&buf, []byte(algo))
+	sshWriteString(&buf, []byte(curveName))
+	sshWriteString(&buf, elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+	return rawSSHPublicKey{algo: algo, blob: buf.Bytes()}, nil
+}
+
+func sshEd448PublicKey(pub ed448.PublicKey) (ssh.PublicKey, error) {
+	var buf bytes.Buffer
+	sshWriteString(&buf, []byte("ssh-ed448"))
+	sshWriteString(&buf, []byte(pub))
+	return rawSSHPublicKey{algo: "ssh-ed448", blob: buf.Bytes()}, nil
+}